@@ -0,0 +1,45 @@
+// Command goproxy is a local Go module proxy backed by the module
+// download cache, with an allowlisted fallback to a real upstream.
+// mcp-go-agent starts it so the container can resolve modules over a
+// loopback HTTP listener without direct network egress from every `go`
+// invocation.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/smishi204/opendoor/internal/goproxy"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8081", "loopback address to listen on")
+	cacheDir := flag.String("cache", "/home/mcpuser/go/pkg/mod/cache/download", "module download cache directory")
+	upstream := flag.String("upstream", "https://proxy.golang.org", "upstream proxy to fall back to on a cache miss")
+	allowed := flag.String("allow", "proxy.golang.org", "comma-separated list of upstream hosts allowed for fallback")
+	flag.Parse()
+
+	server := &goproxy.Server{
+		CacheDir:     *cacheDir,
+		Upstream:     *upstream,
+		AllowedHosts: splitCSV(*allowed),
+	}
+	log.Printf("goproxy: listening on %s, cache=%s, upstream=%s", *addr, *cacheDir, *upstream)
+	if err := server.ListenAndServe(*addr); err != nil {
+		log.Fatalf("goproxy: %v", err)
+	}
+}
+
+func splitCSV(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			if i > start {
+				out = append(out, s[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
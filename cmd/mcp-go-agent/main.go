@@ -0,0 +1,77 @@
+// Command mcp-go-agent is the container entrypoint that exposes Go
+// tooling to an MCP host over stdio. It runs in place of a bare
+// `go run`/`go build` CMD so the host gets structured tools instead of
+// raw shell access.
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+	"github.com/smishi204/opendoor/internal/tools/coverage"
+	"github.com/smishi204/opendoor/internal/tools/crossbuild"
+	"github.com/smishi204/opendoor/internal/tools/golsp"
+	"github.com/smishi204/opendoor/internal/tools/lint"
+	"github.com/smishi204/opendoor/internal/tools/modproxy"
+	"github.com/smishi204/opendoor/internal/tools/toolchain"
+	"github.com/smishi204/opendoor/internal/tools/watch"
+)
+
+// goproxyStartupTimeout bounds how long startGoproxy waits for the
+// local proxy's loopback listener to come up before giving up and
+// serving anyway; GOPROXY's fallBackOnError leg (see modproxy.OnlineGOPROXY)
+// covers the rest if it never does.
+const goproxyStartupTimeout = 5 * time.Second
+
+const workspace = "/workspace"
+
+func main() {
+	startGoproxy()
+
+	server := mcp.NewServer(os.Stdout)
+
+	if err := golsp.Register(server, workspace); err != nil {
+		log.Fatalf("mcp-go-agent: %v", err)
+	}
+	if err := toolchain.Register(server); err != nil {
+		log.Fatalf("mcp-go-agent: %v", err)
+	}
+	watch.Register(server, workspace)
+	lint.Register(server, workspace)
+	crossbuild.Register(server, workspace)
+	coverage.Register(server, workspace)
+	modproxy.Register(server, workspace)
+
+	if err := server.Serve(os.Stdin); err != nil {
+		log.Fatalf("mcp-go-agent: serve: %v", err)
+	}
+}
+
+// startGoproxy launches the local module proxy and blocks until its
+// loopback listener accepts connections (or goproxyStartupTimeout
+// elapses), so the first tool call that shells out to `go` doesn't race
+// the listener coming up.
+func startGoproxy() {
+	cmd := exec.Command("goproxy")
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		log.Printf("mcp-go-agent: start goproxy: %v", err)
+		return
+	}
+	go cmd.Wait()
+
+	deadline := time.Now().Add(goproxyStartupTimeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", modproxy.LocalAddr, 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	log.Printf("mcp-go-agent: goproxy did not come up on %s within %s, continuing", modproxy.LocalAddr, goproxyStartupTimeout)
+}
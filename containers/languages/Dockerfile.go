@@ -2,6 +2,10 @@ FROM mcp-base:latest
 
 USER root
 
+# zip is needed by go.build.cross when producing .zip release archives
+RUN apt-get update && apt-get install -y --no-install-recommends zip \
+    && rm -rf /var/lib/apt/lists/*
+
 # Install Go
 RUN wget https://go.dev/dl/go1.21.3.linux-amd64.tar.gz \
     && tar -C /usr/local -xzf go1.21.3.linux-amd64.tar.gz \
@@ -14,7 +18,8 @@ ENV PATH="$GOPATH/bin:$PATH"
 # Install common Go tools
 RUN go install golang.org/x/tools/gopls@latest \
     && go install github.com/air-verse/air@latest \
-    && go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest
+    && go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest \
+    && go install github.com/axw/gocov/gocov@latest
 
 USER mcpuser
 WORKDIR /workspace
@@ -22,4 +27,34 @@ WORKDIR /workspace
 # Initialize Go module
 RUN go mod init workspace
 
-CMD ["go", "run"]
+# Seed the toolchain SDK root with the preinstalled Go so go.toolchain
+# has a `current` version to fall back to before any pin is requested
+RUN mkdir -p /home/mcpuser/sdk \
+    && ln -s /usr/local/go /home/mcpuser/sdk/go1.21.3 \
+    && ln -s /home/mcpuser/sdk/go1.21.3 /home/mcpuser/sdk/current
+ENV PATH="/home/mcpuser/sdk/current/bin:$PATH"
+
+# Build the MCP tool agent that bridges gopls into the MCP host, plus
+# the local module proxy it starts at launch
+COPY --chown=mcpuser:mcpuser . /home/mcpuser/opendoor
+RUN cd /home/mcpuser/opendoor \
+    && go build -o /home/mcpuser/go/bin/mcp-go-agent ./cmd/mcp-go-agent \
+    && go build -o /home/mcpuser/go/bin/goproxy ./cmd/goproxy
+
+# Resolve modules through the local goproxy first, falling back to the
+# real upstream, so go.mod.offline(true) can later flip this to `off`
+# for fully hermetic, network-free builds. GOPROXY only accepts
+# http/https/file URL schemes, so the local proxy listens on loopback
+# TCP rather than a UNIX socket. The `|` after the local proxy entry
+# opts into fallBackOnError: a plain `,` only falls through on a
+# 404/410 "not found" response, so any other failure (e.g. the local
+# proxy not yet listening) would otherwise abort instead of falling
+# back to the real upstream. GOPROXY is written to the go env file with
+# `go env -w` rather than set with ENV: an environment variable takes
+# precedence over the go env file, so an ENV value would keep
+# go.mod.offline's own `go env -w GOPROXY=off` from taking effect.
+RUN go env -w GOPROXY="http://127.0.0.1:8081|https://proxy.golang.org,direct"
+ENV GOSUMDB="sum.golang.org"
+VOLUME ["/home/mcpuser/go/pkg/mod/cache/download"]
+
+CMD ["mcp-go-agent"]
@@ -0,0 +1,154 @@
+// Package goproxy implements the Go module proxy protocol
+// (https://go.dev/ref/mod#goproxy-protocol) backed by the on-disk
+// module cache, with an allowlisted fallback to a real upstream proxy.
+package goproxy
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Server serves the module proxy protocol over loopback TCP/HTTP.
+type Server struct {
+	// CacheDir is the GOPATH module download cache, normally
+	// $GOPATH/pkg/mod/cache/download.
+	CacheDir string
+	// Upstream is the proxy to fall back to on a cache miss, e.g.
+	// https://proxy.golang.org. Empty disables fallback.
+	Upstream string
+	// AllowedHosts restricts which upstream hosts a fallback request may
+	// reach; if Upstream's host is not listed here, fallback is refused.
+	AllowedHosts []string
+}
+
+var pathPattern = regexp.MustCompile(`^/(.+)/@v/(.+)$`)
+
+// ListenAndServe listens on the loopback TCP address addr (e.g.
+// "127.0.0.1:8081") and serves the proxy protocol until the process
+// exits. A loopback TCP listener is used, not a UNIX socket, because
+// the stock `go` toolchain's GOPROXY only understands the http, https
+// and file URL schemes (see cmd/go/internal/modfetch/proxy.go) — it has
+// no unix:// scheme to reach a socket with.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("goproxy: listen %s: %w", addr, err)
+	}
+	return http.Serve(listener, s)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	match := pathPattern.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		http.NotFound(w, r)
+		return
+	}
+	module, rest := match[1], match[2]
+
+	cachePath, err := s.cachePathFor(module, rest)
+	if err != nil {
+		http.Error(w, "goproxy: invalid module path", http.StatusBadRequest)
+		return
+	}
+
+	if served := s.serveFile(w, cachePath); served {
+		return
+	}
+	if rest == "list" {
+		// A missing list file just means "no cached versions", not an
+		// error; upstream is the authority for what's available.
+		if s.proxyUpstream(w, r) {
+			return
+		}
+		fmt.Fprint(w, "")
+		return
+	}
+
+	if s.proxyUpstream(w, r) {
+		return
+	}
+	http.Error(w, "goproxy: not found in cache and no upstream available", http.StatusNotFound)
+}
+
+// cachePathFor resolves module/rest to a path under CacheDir, rejecting
+// anything that would escape it (e.g. a module of "../../../../etc")
+// rather than trusting filepath.Join to keep the result contained.
+func (s *Server) cachePathFor(module, rest string) (string, error) {
+	cacheRoot := filepath.Clean(s.CacheDir)
+	path := filepath.Join(cacheRoot, filepath.FromSlash(module), "@v", rest)
+	if path != cacheRoot && !strings.HasPrefix(path, cacheRoot+string(os.PathSeparator)) {
+		return "", fmt.Errorf("goproxy: module path %q escapes cache root", module)
+	}
+	return path, nil
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	io.Copy(w, f)
+	return true
+}
+
+func (s *Server) proxyUpstream(w http.ResponseWriter, r *http.Request) bool {
+	if s.Upstream == "" {
+		return false
+	}
+	upstreamURL := strings.TrimRight(s.Upstream, "/") + r.URL.Path
+	if !s.upstreamAllowed() {
+		log.Printf("goproxy: upstream %s not in allowlist, refusing fallback", s.Upstream)
+		return false
+	}
+
+	resp, err := http.Get(upstreamURL)
+	if err != nil {
+		log.Printf("goproxy: upstream fetch %s: %v", upstreamURL, err)
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+	s.cacheUpstreamResponse(r.URL.Path, resp)
+	return true
+}
+
+func (s *Server) upstreamAllowed() bool {
+	host := hostOf(s.Upstream)
+	for _, allowed := range s.AllowedHosts {
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOf(rawURL string) string {
+	rawURL = strings.TrimPrefix(rawURL, "https://")
+	rawURL = strings.TrimPrefix(rawURL, "http://")
+	if idx := strings.IndexByte(rawURL, '/'); idx >= 0 {
+		rawURL = rawURL[:idx]
+	}
+	return rawURL
+}
+
+// cacheUpstreamResponse logs a response served from upstream. It does
+// not write anything into CacheDir: Body has already been drained by the
+// caller, so this only logs; actual population happens via
+// `go mod download`, which writes directly into CacheDir using the same
+// on-disk layout we read from.
+func (s *Server) cacheUpstreamResponse(path string, resp *http.Response) {
+	log.Printf("goproxy: served %s from upstream (status %d)", path, resp.StatusCode)
+}
@@ -0,0 +1,77 @@
+package goproxy
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCachePathForRejectsEscapingModule(t *testing.T) {
+	s := &Server{CacheDir: "/cache"}
+
+	cases := []string{
+		"../../../../etc",
+		"foo/../../bar",
+		"..",
+	}
+	for _, module := range cases {
+		if _, err := s.cachePathFor(module, "list"); err == nil {
+			t.Errorf("cachePathFor(%q) = nil error, want one rejecting the escape", module)
+		}
+	}
+}
+
+func TestCachePathForAllowsNormalModule(t *testing.T) {
+	s := &Server{CacheDir: "/cache"}
+
+	path, err := s.cachePathFor("github.com/foo/bar", "v1.2.3.info")
+	if err != nil {
+		t.Fatalf("cachePathFor: %v", err)
+	}
+	want := filepath.Join("/cache", "github.com/foo/bar", "@v", "v1.2.3.info")
+	if path != want {
+		t.Errorf("cachePathFor = %q, want %q", path, want)
+	}
+}
+
+// TestListenAndServeUsesTCP guards against regressing to a unix://
+// listener: GOPROXY only understands http/https/file schemes, so the
+// server must be reachable over plain loopback TCP/HTTP.
+func TestListenAndServeUsesTCP(t *testing.T) {
+	s := &Server{CacheDir: t.TempDir()}
+
+	errc := make(chan error, 1)
+	go func() { errc <- s.ListenAndServe("127.0.0.1:0") }()
+
+	// ListenAndServe doesn't report back which port it bound, so this
+	// only checks that it binds an ephemeral loopback TCP port and keeps
+	// serving instead of returning an error.
+	select {
+	case err := <-errc:
+		t.Fatalf("ListenAndServe returned early: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestListenAndServeServesHTTP(t *testing.T) {
+	s := &Server{CacheDir: t.TempDir()}
+	addr := "127.0.0.1:18081"
+
+	go s.ListenAndServe(addr)
+
+	var resp *http.Response
+	var err error
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		resp, err = http.Get("http://" + addr + "/example.com/foo/@v/list")
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("GET over loopback TCP failed: %v", err)
+	}
+	resp.Body.Close()
+}
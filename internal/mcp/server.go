@@ -0,0 +1,133 @@
+// Package mcp implements a minimal MCP tool host: a line-delimited JSON
+// protocol over stdio that dispatches requests to registered Tools and
+// lets long-running tools push asynchronous notifications back to the
+// client.
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+)
+
+// Tool is a single MCP tool exposed by the agent.
+type Tool struct {
+	Name        string
+	Description string
+	Handler     func(req *Request, s *Server) (any, error)
+}
+
+// Request is one incoming tool call.
+type Request struct {
+	ID     string          `json:"id"`
+	Tool   string          `json:"tool"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	ID     string `json:"id"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Notification is an out-of-band message a tool emits while it runs,
+// e.g. streamed build output or test events.
+type Notification struct {
+	ID     string `json:"id"`
+	Tool   string `json:"tool"`
+	Stream string `json:"stream"`
+	Data   any    `json:"data"`
+}
+
+// Server dispatches Requests read from stdin to registered Tools,
+// running each handler concurrently, and writes responses/notifications
+// to stdout. It is safe for concurrent use by multiple in-flight tool
+// handlers.
+type Server struct {
+	out   *json.Encoder
+	outMu sync.Mutex
+	tools map[string]Tool
+}
+
+// NewServer returns a Server that writes responses and notifications to w.
+func NewServer(w io.Writer) *Server {
+	return &Server{
+		out:   json.NewEncoder(w),
+		tools: make(map[string]Tool),
+	}
+}
+
+// Register adds a tool to the registry. It panics on duplicate names
+// since that indicates a wiring bug in main, not a runtime condition.
+func (s *Server) Register(t Tool) {
+	if _, exists := s.tools[t.Name]; exists {
+		panic(fmt.Sprintf("mcp: tool %q already registered", t.Name))
+	}
+	s.tools[t.Name] = t
+}
+
+// Notify sends an asynchronous message associated with an in-flight
+// request. Tools use this to stream output before returning their
+// final result.
+func (s *Server) Notify(n Notification) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if err := s.out.Encode(n); err != nil {
+		log.Printf("mcp: notify failed: %v", err)
+	}
+}
+
+// Serve reads newline-delimited Requests from r until EOF, dispatching
+// each to its registered tool in its own goroutine and writing back a
+// response. Dispatch is concurrent so a slow tool call (a long test run,
+// a cross-compile matrix) can't block unrelated calls on the same
+// connection; Serve waits for every in-flight handler to finish before
+// returning.
+func (s *Server) Serve(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var wg sync.WaitGroup
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.reply(response{Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+		wg.Add(1)
+		go func(req *Request) {
+			defer wg.Done()
+			s.dispatch(req)
+		}(&req)
+	}
+	wg.Wait()
+	return scanner.Err()
+}
+
+func (s *Server) dispatch(req *Request) {
+	tool, ok := s.tools[req.Tool]
+	if !ok {
+		s.reply(response{ID: req.ID, Error: fmt.Sprintf("unknown tool %q", req.Tool)})
+		return
+	}
+	result, err := tool.Handler(req, s)
+	if err != nil {
+		s.reply(response{ID: req.ID, Error: err.Error()})
+		return
+	}
+	s.reply(response{ID: req.ID, Result: result})
+}
+
+func (s *Server) reply(resp response) {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if err := s.out.Encode(resp); err != nil {
+		log.Printf("mcp: reply failed: %v", err)
+	}
+}
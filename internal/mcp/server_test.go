@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeDispatchesConcurrently(t *testing.T) {
+	unblock := make(chan struct{})
+	fastDone := make(chan struct{})
+
+	s := NewServer(&bytes.Buffer{})
+	s.Register(Tool{
+		Name: "slow",
+		Handler: func(req *Request, _ *Server) (any, error) {
+			<-unblock
+			return "ok", nil
+		},
+	})
+	s.Register(Tool{
+		Name: "fast",
+		Handler: func(req *Request, _ *Server) (any, error) {
+			close(fastDone)
+			return "ok", nil
+		},
+	})
+
+	input := strings.NewReader(`{"id":"1","tool":"slow"}` + "\n" + `{"id":"2","tool":"fast"}` + "\n")
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(input) }()
+
+	select {
+	case <-fastDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("fast tool call never ran; a slow in-flight call blocked dispatch")
+	}
+
+	close(unblock)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve did not return after the slow handler unblocked")
+	}
+}
@@ -0,0 +1,190 @@
+package toolchain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// dlBaseURL is where official Go SDK tarballs are published.
+const dlBaseURL = "https://go.dev/dl/"
+
+// dlListURL is go.dev's machine-readable index of published SDK
+// releases, documented at https://go.dev/dl/?mode=json. include=all is
+// required or go.dev only returns the latest couple of stable release
+// lines, which would silently hide most versions it still serves
+// tarballs for.
+const dlListURL = dlBaseURL + "?mode=json&include=all"
+
+// dlRelease mirrors the subset of go.dev's release index we need.
+type dlRelease struct {
+	Version string `json:"version"` // e.g. "go1.22.5"
+	Stable  bool   `json:"stable"`
+}
+
+// Available returns the stable Go SDK versions go.dev currently
+// publishes, for go.toolchain.list's "available" field.
+func Available() ([]string, error) {
+	return fetchAvailable(dlListURL)
+}
+
+func fetchAvailable(url string) ([]string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("toolchain: fetch available versions: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("toolchain: fetch available versions: unexpected status %s", resp.Status)
+	}
+
+	var releases []dlRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("toolchain: decode available versions: %w", err)
+	}
+
+	var versions []string
+	for _, r := range releases {
+		if r.Stable {
+			versions = append(versions, versionFromDir(r.Version))
+		}
+	}
+	return versions, nil
+}
+
+// tarballURL returns the download URL for a given version/GOOS/GOARCH
+// combination, or ok=false if go.dev doesn't publish a prebuilt tarball
+// for that host, confirmed with a HEAD request against the stable
+// go.dev/dl naming scheme.
+func tarballURL(version, goos, goarch string) (url string, ok bool) {
+	name := fmt.Sprintf("go%s.%s-%s.tar.gz", version, goos, goarch)
+	url = dlBaseURL + name
+
+	resp, err := http.Head(url)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+	return url, resp.StatusCode == http.StatusOK
+}
+
+// checksumURL returns the URL of the published .sha256 companion file
+// for a tarball URL; go.dev publishes one checksum file per tarball (e.g.
+// go1.22.5.linux-amd64.tar.gz.sha256), not one per version.
+func checksumURL(tarballURL string) string {
+	return tarballURL + ".sha256"
+}
+
+func downloadAndVerify(url, version, dest string) error {
+	tmpFile, err := os.CreateTemp("", "opendoor-go-sdk-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	sum := sha256.New()
+	if err := fetch(url, io.MultiWriter(tmpFile, sum)); err != nil {
+		return fmt.Errorf("toolchain: download %s: %w", url, err)
+	}
+
+	wantHex, err := fetchString(checksumURL(url))
+	if err != nil {
+		return fmt.Errorf("toolchain: fetch checksum: %w", err)
+	}
+	gotHex := hex.EncodeToString(sum.Sum(nil))
+	if gotHex != wantHex {
+		return fmt.Errorf("toolchain: checksum mismatch for %s: got %s want %s", url, gotHex, wantHex)
+	}
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	cmd := exec.Command("tar", "-C", dest, "--strip-components=1", "-xzf", tmpFile.Name())
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func fetch(url string, w io.Writer) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func fetchString(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// buildFromSource builds version from the Go source tree using the
+// bootstrap SDK, for hosts/arches with no prebuilt tarball.
+func (m *Manager) buildFromSource(version, dest string) error {
+	bootstrapDir := filepath.Join(m.root, "go"+bootstrapVersion)
+	if _, err := os.Stat(bootstrapDir); os.IsNotExist(err) {
+		url, ok := tarballURL(bootstrapVersion, "linux", "amd64")
+		if !ok {
+			return fmt.Errorf("toolchain: no bootstrap tarball published for go%s linux/amd64", bootstrapVersion)
+		}
+		if err := downloadAndVerify(url, bootstrapVersion, bootstrapDir); err != nil {
+			return fmt.Errorf("toolchain: install bootstrap %s: %w", bootstrapVersion, err)
+		}
+	}
+
+	src := filepath.Join(os.TempDir(), "go-src-"+version)
+	if err := fetchSource(version, src); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("./make.bash")
+	cmd.Dir = filepath.Join(src, "src")
+	cmd.Env = append(os.Environ(), "GOROOT_BOOTSTRAP="+bootstrapDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("toolchain: build go%s from source: %w", version, err)
+	}
+	return os.Rename(src, dest)
+}
+
+func fetchSource(version, dest string) error {
+	url := fmt.Sprintf("%sgo%s.src.tar.gz", dlBaseURL, version)
+	tmpFile, err := os.CreateTemp("", "opendoor-go-src-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+	if err := fetch(url, tmpFile); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	cmd := exec.Command("tar", "-C", dest, "--strip-components=1", "-xzf", tmpFile.Name())
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
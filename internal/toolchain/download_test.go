@@ -0,0 +1,61 @@
+package toolchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChecksumURLIsPerTarball(t *testing.T) {
+	tarball := dlBaseURL + "go1.22.5.linux-amd64.tar.gz"
+	want := tarball + ".sha256"
+	if got := checksumURL(tarball); got != want {
+		t.Errorf("checksumURL(%q) = %q, want %q", tarball, got, want)
+	}
+}
+
+func TestFetchStringErrorsOnNon200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("<html>not found</html>"))
+	}))
+	defer srv.Close()
+
+	if _, err := fetchString(srv.URL); err == nil {
+		t.Fatal("fetchString: expected an error on a 404 response, got nil")
+	}
+}
+
+func TestFetchStringReturnsBodyOn200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("deadbeef"))
+	}))
+	defer srv.Close()
+
+	got, err := fetchString(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchString: %v", err)
+	}
+	if strings.TrimSpace(got) != "deadbeef" {
+		t.Errorf("fetchString = %q, want deadbeef", got)
+	}
+}
+
+func TestFetchAvailableFiltersUnstableReleases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"version":"go1.22.5","stable":true},
+			{"version":"go1.23rc1","stable":false}
+		]`))
+	}))
+	defer srv.Close()
+
+	versions, err := fetchAvailable(srv.URL)
+	if err != nil {
+		t.Fatalf("fetchAvailable: %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "1.22.5" {
+		t.Errorf("fetchAvailable = %v, want [1.22.5]", versions)
+	}
+}
@@ -0,0 +1,38 @@
+package toolchain
+
+import (
+	"os"
+	"syscall"
+)
+
+// FileLock is a simple advisory lock used to serialize concurrent SDK
+// installs into the same SDKRoot.
+type FileLock struct {
+	path string
+	file *os.File
+}
+
+// NewFileLock returns a FileLock backed by a lockfile at path.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// Lock blocks until the lock is acquired.
+func (l *FileLock) Lock() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return err
+	}
+	l.file = f
+	return nil
+}
+
+// Unlock releases the lock acquired by Lock.
+func (l *FileLock) Unlock() error {
+	defer l.file.Close()
+	return syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+}
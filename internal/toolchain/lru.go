@@ -0,0 +1,86 @@
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// pruneThresholdBytes is the free-disk-space floor below which the
+// least-recently-used SDK is evicted. Overridable for testing.
+var pruneThresholdBytes uint64 = 2 << 30 // 2 GiB
+
+// touch records dir as most-recently-used by bumping its mtime, since
+// SDK directories aren't otherwise written to after install.
+func touch(dir string) {
+	now := time.Now()
+	os.Chtimes(dir, now, now)
+}
+
+// pruneLRU removes the least-recently-used installed SDKs while free
+// disk space under SDKRoot is below pruneThresholdBytes.
+func (m *Manager) pruneLRU() error {
+	for {
+		free, err := freeBytes(m.root)
+		if err != nil {
+			return err
+		}
+		if free >= pruneThresholdBytes {
+			return nil
+		}
+
+		victim, err := m.oldestUnused()
+		if err != nil {
+			return err
+		}
+		if victim == "" {
+			return nil // nothing left to evict
+		}
+		if err := os.RemoveAll(victim); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Manager) oldestUnused() (string, error) {
+	entries, err := os.ReadDir(m.root)
+	if err != nil {
+		return "", err
+	}
+
+	current, _ := os.Readlink(CurrentLink)
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	for _, e := range entries {
+		if !isSDKDir(e.Name()) {
+			continue
+		}
+		path := filepath.Join(m.root, e.Name())
+		if path == current {
+			continue
+		}
+		info, err := os.Stat(path) // follows symlinks, unlike e.Info()
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		candidates = append(candidates, candidate{path, info.ModTime()})
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].modTime.Before(candidates[j].modTime) })
+	return candidates[0].path, nil
+}
+
+func freeBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,120 @@
+// Package toolchain installs and switches between multiple Go SDKs
+// inside the container, independent of whatever version the base image
+// shipped with.
+package toolchain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+)
+
+// SDKRoot is where per-version Go SDKs are unpacked.
+const SDKRoot = "/home/mcpuser/sdk"
+
+// CurrentLink is the symlink PATH is configured to resolve `go` through.
+const CurrentLink = SDKRoot + "/current"
+
+// bootstrapVersion is the SDK used to build from source when no
+// prebuilt tarball exists for the host arch, mirroring the bootstrap
+// chain Go itself has required since 1.5.
+const bootstrapVersion = "1.17"
+
+// Manager installs, switches and prunes Go SDKs under SDKRoot.
+type Manager struct {
+	root string
+	lock *FileLock
+}
+
+// NewManager returns a Manager rooted at SDKRoot.
+func NewManager() (*Manager, error) {
+	if err := os.MkdirAll(SDKRoot, 0o755); err != nil {
+		return nil, fmt.Errorf("toolchain: create sdk root: %w", err)
+	}
+	return &Manager{
+		root: SDKRoot,
+		lock: NewFileLock(filepath.Join(SDKRoot, ".lock")),
+	}, nil
+}
+
+// Installed returns the versions currently unpacked under SDKRoot.
+func (m *Manager) Installed() ([]string, error) {
+	entries, err := os.ReadDir(m.root)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, e := range entries {
+		if !isSDKDir(e.Name()) || !isDir(filepath.Join(m.root, e.Name())) {
+			continue
+		}
+		versions = append(versions, versionFromDir(e.Name()))
+	}
+	return versions, nil
+}
+
+// isDir reports whether path is a directory, following symlinks (the
+// preinstalled SDK is seeded as a symlink, which os.DirEntry.IsDir
+// would otherwise report as not-a-directory).
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// versionPattern matches the version-number shapes go.dev publishes
+// (e.g. "1.22.5", "1.21rc3", "1.22beta1"), which covers every version
+// Available() returns. version is client-controlled and gets joined into dir below,
+// so anything outside this shape (e.g. "../../../../tmp/evil") is
+// rejected before it ever reaches a path.
+var versionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}(rc|beta)?[0-9]*$`)
+
+// Use installs version if necessary and repoints CurrentLink at it.
+// Concurrent calls are serialized via a lockfile so two requests can't
+// unpack into the same directory at once.
+func (m *Manager) Use(version string) error {
+	if !versionPattern.MatchString(version) {
+		return fmt.Errorf("toolchain: invalid version %q", version)
+	}
+
+	if err := m.lock.Lock(); err != nil {
+		return fmt.Errorf("toolchain: acquire lock: %w", err)
+	}
+	defer m.lock.Unlock()
+
+	dir := filepath.Join(m.root, "go"+version)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := m.install(version, dir); err != nil {
+			return err
+		}
+	}
+
+	touch(dir)
+	if err := m.pruneLRU(); err != nil {
+		return fmt.Errorf("toolchain: prune: %w", err)
+	}
+
+	tmp := CurrentLink + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(dir, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, CurrentLink)
+}
+
+func (m *Manager) install(version, dir string) error {
+	url, ok := tarballURL(version, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return m.buildFromSource(version, dir)
+	}
+	return downloadAndVerify(url, version, dir)
+}
+
+func isSDKDir(name string) bool {
+	return len(name) > 2 && name[:2] == "go" && name != "go"
+}
+
+func versionFromDir(name string) string {
+	return name[2:]
+}
@@ -0,0 +1,64 @@
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSDKDir(t *testing.T) {
+	cases := map[string]bool{
+		"go1.21.3": true,
+		"go1.17":   true,
+		"go":       false,
+		"current":  false,
+		".lock":    false,
+	}
+	for name, want := range cases {
+		if got := isSDKDir(name); got != want {
+			t.Errorf("isSDKDir(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestVersionFromDir(t *testing.T) {
+	if got := versionFromDir("go1.22.5"); got != "1.22.5" {
+		t.Errorf("versionFromDir(go1.22.5) = %q, want 1.22.5", got)
+	}
+}
+
+func TestVersionPatternRejectsPathTraversal(t *testing.T) {
+	valid := []string{"1.22.5", "1.17", "1.21rc3", "1.22beta1"}
+	for _, v := range valid {
+		if !versionPattern.MatchString(v) {
+			t.Errorf("versionPattern.MatchString(%q) = false, want true", v)
+		}
+	}
+
+	invalid := []string{"", "../../../../tmp/evil", "1.22.5/../../x", "1.22.5; rm -rf /"}
+	for _, v := range invalid {
+		if versionPattern.MatchString(v) {
+			t.Errorf("versionPattern.MatchString(%q) = true, want false", v)
+		}
+	}
+}
+
+func TestInstalledIncludesSymlinkedSDK(t *testing.T) {
+	root := t.TempDir()
+	real := filepath.Join(t.TempDir(), "real-go")
+	if err := os.MkdirAll(real, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(real, filepath.Join(root, "go1.21.3")); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := &Manager{root: root}
+	versions, err := mgr.Installed()
+	if err != nil {
+		t.Fatalf("Installed: %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "1.21.3" {
+		t.Errorf("Installed() = %v, want [1.21.3]", versions)
+	}
+}
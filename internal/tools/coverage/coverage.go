@@ -0,0 +1,207 @@
+// Package coverage implements the go.test and go.coverage MCP tools:
+// streaming per-test events from `go test -json` and normalizing
+// coverage profiles through gocov into a package/function/statement tree.
+package coverage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// profilePath is where the last coverage run is cached so a follow-up
+// go.coverage.diff call has a baseline to compare against.
+func profilePath(workspace string) string {
+	return filepath.Join(workspace, ".opendoor", "coverage.out")
+}
+
+// TestOptions configures a go.test run.
+type TestOptions struct {
+	Packages []string `json:"packages"`
+	Race     bool     `json:"race"`
+	Count    int      `json:"count"`
+}
+
+// TestEvent mirrors one `go test -json` event.
+type TestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test,omitempty"`
+	Elapsed float64 `json:"Elapsed,omitempty"`
+	Output  string  `json:"Output,omitempty"`
+}
+
+// RunTests runs `go test -json` and invokes onEvent for each streamed
+// event as it arrives, so callers can forward them as MCP notifications
+// in real time rather than waiting for the whole run to finish.
+func RunTests(workspace string, opts TestOptions, onEvent func(TestEvent)) error {
+	args := buildTestArgs(opts, "-json")
+	cmd := exec.Command("go", args...)
+	cmd.Dir = workspace
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("coverage: start go test: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event TestEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		onEvent(event)
+	}
+
+	// go test exits non-zero on test failure; that's expected and
+	// already reflected in the streamed events, not a tool error.
+	_ = cmd.Wait()
+	return nil
+}
+
+func buildTestArgs(opts TestOptions, extra ...string) []string {
+	args := append([]string{"test"}, extra...)
+	if opts.Race {
+		args = append(args, "-race")
+	}
+	if opts.Count > 0 {
+		args = append(args, "-count", fmt.Sprint(opts.Count))
+	}
+	// "--" stops go test's flag parser from treating a package selector
+	// that happens to start with "-" (e.g. "-exec=...") as a flag; opts
+	// is client-controlled, so without this a crafted Packages entry
+	// could smuggle arbitrary flags — including -exec — into the command.
+	args = append(args, "--")
+	if len(opts.Packages) > 0 {
+		args = append(args, opts.Packages...)
+	} else {
+		args = append(args, "./...")
+	}
+	return args
+}
+
+// PackageCoverage is one package's normalized coverage tree.
+type PackageCoverage struct {
+	Package   string             `json:"package"`
+	Percent   float64            `json:"percent"`
+	Functions []FunctionCoverage `json:"functions"`
+}
+
+// FunctionCoverage is one function's statement coverage, including
+// uncovered line ranges suitable for gutter rendering.
+type FunctionCoverage struct {
+	Name      string   `json:"name"`
+	Percent   float64  `json:"percent"`
+	Uncovered []string `json:"uncovered"` // "file:startLine-endLine"
+}
+
+// Report is the aggregate result of a go.coverage run.
+type Report struct {
+	Percent  float64           `json:"percent"`
+	Packages []PackageCoverage `json:"packages"`
+}
+
+// CoverageOptions configures a go.coverage run.
+type CoverageOptions struct {
+	Packages []string `json:"packages"`
+	Race     bool     `json:"race"`
+}
+
+// RunCoverage runs `go test -coverprofile`, converts the profile via
+// `gocov convert`, and caches the raw profile at profilePath(workspace)
+// so a later go.coverage.diff call can highlight what changed.
+func RunCoverage(workspace string, opts CoverageOptions) (*Report, error) {
+	if err := os.MkdirAll(filepath.Dir(profilePath(workspace)), 0o755); err != nil {
+		return nil, err
+	}
+
+	profile := profilePath(workspace)
+	args := buildTestArgs(TestOptions{Packages: opts.Packages, Race: opts.Race}, "-coverprofile", profile)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = workspace
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Coverage still matters when tests fail; don't bail out on a
+	// non-zero exit, the profile is written regardless.
+	_ = cmd.Run()
+
+	converted, err := exec.Command("gocov", "convert", profile).Output()
+	if err != nil {
+		return nil, fmt.Errorf("coverage: gocov convert: %w", err)
+	}
+	return parseGocovJSON(converted)
+}
+
+// gocovPackage/gocovFunction/gocovStatement mirror the subset of
+// gocov's JSON schema we normalize into a Report.
+type gocovStatement struct {
+	Start   int `json:"Start"`
+	End     int `json:"End"`
+	Reached int `json:"Reached"`
+}
+
+type gocovFunction struct {
+	Name       string           `json:"Name"`
+	File       string           `json:"File"`
+	Statements []gocovStatement `json:"Statements"`
+}
+
+type gocovPackage struct {
+	Name      string          `json:"Name"`
+	Functions []gocovFunction `json:"Functions"`
+}
+
+type gocovReport struct {
+	Packages []gocovPackage `json:"Packages"`
+}
+
+func parseGocovJSON(raw []byte) (*Report, error) {
+	var g gocovReport
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return nil, fmt.Errorf("coverage: parse gocov output: %w", err)
+	}
+
+	report := &Report{}
+	var totalStatements, coveredStatements int
+	for _, pkg := range g.Packages {
+		pc := PackageCoverage{Package: pkg.Name}
+		var pkgTotal, pkgCovered int
+		for _, fn := range pkg.Functions {
+			fc := FunctionCoverage{Name: fn.Name}
+			var fnTotal, fnCovered int
+			for _, stmt := range fn.Statements {
+				fnTotal++
+				if stmt.Reached > 0 {
+					fnCovered++
+				} else {
+					fc.Uncovered = append(fc.Uncovered, fmt.Sprintf("%s:%d-%d", fn.File, stmt.Start, stmt.End))
+				}
+			}
+			fc.Percent = percent(fnCovered, fnTotal)
+			pc.Functions = append(pc.Functions, fc)
+			pkgTotal += fnTotal
+			pkgCovered += fnCovered
+		}
+		pc.Percent = percent(pkgCovered, pkgTotal)
+		report.Packages = append(report.Packages, pc)
+		totalStatements += pkgTotal
+		coveredStatements += pkgCovered
+	}
+	report.Percent = percent(coveredStatements, totalStatements)
+	return report, nil
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100.0
+	}
+	return 100.0 * float64(covered) / float64(total)
+}
@@ -0,0 +1,35 @@
+package coverage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildTestArgsSeparatesFlagsFromPackages(t *testing.T) {
+	args := buildTestArgs(TestOptions{Packages: []string{"-exec=evil", "./..."}, Race: true, Count: 2})
+	want := []string{"test", "-race", "-count", "2", "--", "-exec=evil", "./..."}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("buildTestArgs = %v, want %v", args, want)
+	}
+
+	dashIdx, pkgIdx := -1, -1
+	for i, a := range args {
+		if a == "--" {
+			dashIdx = i
+		}
+		if a == "-exec=evil" {
+			pkgIdx = i
+		}
+	}
+	if dashIdx == -1 || pkgIdx == -1 || dashIdx >= pkgIdx {
+		t.Errorf("buildTestArgs must place \"--\" before package args so a package selector starting with \"-\" can't be parsed as a flag, got %v", args)
+	}
+}
+
+func TestBuildTestArgsDefaultsToAllPackages(t *testing.T) {
+	args := buildTestArgs(TestOptions{})
+	want := []string{"test", "--", "./..."}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("buildTestArgs = %v, want %v", args, want)
+	}
+}
@@ -0,0 +1,125 @@
+package coverage
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LineCoverageDiff describes one line range whose coverage state
+// changed between the cached profile and the just-completed run.
+type LineCoverageDiff struct {
+	File  string `json:"file"`
+	Range string `json:"range"`
+	From  string `json:"from"` // "covered" or "uncovered"
+	To    string `json:"to"`
+}
+
+// Diff compares the profile currently cached for workspace against a
+// freshly recorded one, returning the set of line ranges whose coverage
+// changed. It must run before RunCoverage overwrites the cache.
+func Diff(workspace string) ([]LineCoverageDiff, error) {
+	path := profilePath(workspace)
+	baseline, err := readProfileLines(path)
+	if err != nil {
+		return nil, fmt.Errorf("coverage: no cached profile to diff against: %w", err)
+	}
+
+	backup := path + ".prev"
+	if err := os.Rename(path, backup); err != nil {
+		return nil, err
+	}
+
+	if _, err := RunCoverage(workspace, CoverageOptions{}); err != nil {
+		os.Rename(backup, path) // restore the baseline; this run produced no fresh profile
+		return nil, err
+	}
+	current, err := readProfileLines(path)
+	if err != nil {
+		os.Rename(backup, path)
+		return nil, err
+	}
+
+	os.Remove(backup)
+	return diffLines(baseline, current), nil
+}
+
+// profileLine is one line of Go's textual coverage profile format:
+// "file:startLine.startCol,endLine.endCol numStmt count".
+type profileLine struct {
+	block string // everything but the trailing hit count
+	count string
+}
+
+func readProfileLines(path string) (map[string]profileLine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := map[string]profileLine{}
+	for _, raw := range splitLines(data) {
+		if raw == "" || strings.HasPrefix(raw, "mode:") { // skip the "mode: ..." header
+			continue
+		}
+		idx := lastSpace(raw)
+		if idx < 0 {
+			continue
+		}
+		block, count := raw[:idx], raw[idx+1:]
+		lines[block] = profileLine{block: block, count: count}
+	}
+	return lines, nil
+}
+
+func diffLines(before, after map[string]profileLine) []LineCoverageDiff {
+	var diffs []LineCoverageDiff
+	for block, afterLine := range after {
+		beforeLine, existed := before[block]
+		beforeState := "uncovered"
+		if existed && beforeLine.count != "0" {
+			beforeState = "covered"
+		}
+		afterState := "uncovered"
+		if afterLine.count != "0" {
+			afterState = "covered"
+		}
+		if beforeState != afterState {
+			file, rng := splitBlock(block)
+			diffs = append(diffs, LineCoverageDiff{File: file, Range: rng, From: beforeState, To: afterState})
+		}
+	}
+	return diffs
+}
+
+func splitBlock(block string) (file, rng string) {
+	for i := 0; i < len(block); i++ {
+		if block[i] == ':' {
+			return block[:i], block[i+1:]
+		}
+	}
+	return block, ""
+}
+
+func splitLines(data []byte) []string {
+	var lines []string
+	start := 0
+	for i, b := range data {
+		if b == '\n' {
+			lines = append(lines, string(data[start:i]))
+			start = i + 1
+		}
+	}
+	if start < len(data) {
+		lines = append(lines, string(data[start:]))
+	}
+	return lines
+}
+
+func lastSpace(s string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
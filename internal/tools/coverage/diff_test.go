@@ -0,0 +1,80 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	got := splitLines([]byte("mode: set\nfoo.go:1.1,2.2 1 1\n"))
+	want := []string{"mode: set", "foo.go:1.1,2.2 1 1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitLines = %v, want %v", got, want)
+	}
+}
+
+func TestLastSpace(t *testing.T) {
+	if got := lastSpace("foo.go:1.1,2.2 1 1"); got != 16 {
+		t.Errorf("lastSpace = %d, want 16", got)
+	}
+	if got := lastSpace("no-spaces"); got != -1 {
+		t.Errorf("lastSpace = %d, want -1", got)
+	}
+}
+
+func TestSplitBlock(t *testing.T) {
+	file, rng := splitBlock("foo.go:1.1,2.2 1")
+	if file != "foo.go" || rng != "1.1,2.2 1" {
+		t.Errorf("splitBlock = (%q, %q)", file, rng)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	before := map[string]profileLine{
+		"foo.go:1.1,2.2 1": {count: "0"},
+		"foo.go:3.1,4.2 1": {count: "5"},
+	}
+	after := map[string]profileLine{
+		"foo.go:1.1,2.2 1": {count: "3"},
+		"foo.go:3.1,4.2 1": {count: "5"},
+	}
+	diffs := diffLines(before, after)
+	if len(diffs) != 1 {
+		t.Fatalf("diffLines returned %d diffs, want 1: %+v", len(diffs), diffs)
+	}
+	if diffs[0].File != "foo.go" || diffs[0].From != "uncovered" || diffs[0].To != "covered" {
+		t.Errorf("diffLines[0] = %+v, want uncovered->covered on foo.go", diffs[0])
+	}
+}
+
+func TestDiffRestoresBaselineOnFailure(t *testing.T) {
+	workspace := t.TempDir()
+	path := profilePath(workspace)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	const baseline = "mode: set\nfoo.go:1.1,2.2 1 1\n"
+	if err := os.WriteFile(path, []byte(baseline), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// RunCoverage fails in this environment (no go.mod / no gocov
+	// binary), so Diff must restore the cached baseline rather than
+	// leaving it deleted.
+	if _, err := Diff(workspace); err == nil {
+		t.Fatal("Diff: expected an error from a failing coverage run")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("baseline was not restored: %v", err)
+	}
+	if string(data) != baseline {
+		t.Errorf("restored baseline = %q, want %q", data, baseline)
+	}
+	if _, err := os.Stat(path + ".prev"); !os.IsNotExist(err) {
+		t.Errorf("backup file %s.prev should not remain after restore", path)
+	}
+}
@@ -0,0 +1,54 @@
+package coverage
+
+import (
+	"encoding/json"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Register wires go.test, go.coverage and go.coverage.diff onto s.
+func Register(s *mcp.Server, workspace string) {
+	s.Register(mcp.Tool{
+		Name:        "go.test",
+		Description: "Run go test -json, streaming per-test events as they happen",
+		Handler: func(req *mcp.Request, server *mcp.Server) (any, error) {
+			var opts TestOptions
+			if err := json.Unmarshal(req.Params, &opts); err != nil {
+				return nil, err
+			}
+			var events []TestEvent
+			err := RunTests(workspace, opts, func(e TestEvent) {
+				events = append(events, e)
+				server.Notify(mcp.Notification{ID: req.ID, Tool: "go.test", Stream: e.Action, Data: e})
+			})
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"events": len(events)}, nil
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.coverage",
+		Description: "Run tests with coverage and return a normalized package/function/statement tree",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var opts CoverageOptions
+			if err := json.Unmarshal(req.Params, &opts); err != nil {
+				return nil, err
+			}
+			return RunCoverage(workspace, opts)
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.coverage.diff",
+		Description: "Re-run coverage and report line ranges whose coverage changed since the last run",
+		Handler: func(_ *mcp.Request, _ *mcp.Server) (any, error) {
+			diffs, err := Diff(workspace)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"changed": diffs}, nil
+		},
+	})
+}
@@ -0,0 +1,267 @@
+// Package crossbuild implements the go.build.cross MCP tool: building a
+// matrix of GOOS/GOARCH targets into /workspace/dist with a checksum
+// manifest, in the spirit of the gox-style release pipelines.
+package crossbuild
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Target is one GOOS/GOARCH/CGO_ENABLED combination to build.
+type Target struct {
+	GOOS       string `json:"goos"`
+	GOARCH     string `json:"goarch"`
+	CGOEnabled bool   `json:"cgoEnabled"`
+}
+
+// Request describes one go.build.cross invocation.
+type Request struct {
+	Name         string   `json:"name"`
+	Targets      []Target `json:"targets"`
+	Archive      string   `json:"archive"` // "", "tar.gz" or "zip"
+	Reproducible bool     `json:"reproducible"`
+}
+
+// Artifact is one built binary (and optional archive) plus its checksum.
+type Artifact struct {
+	Target  Target `json:"target"`
+	Path    string `json:"path"`
+	Archive string `json:"archive,omitempty"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest is the machine-readable result of a go.build.cross run.
+type Manifest struct {
+	Artifacts      []Artifact `json:"artifacts"`
+	SHA256SumsPath string     `json:"sha256SumsPath"`
+}
+
+// Run builds every target in req, writing binaries and archives to
+// distDir alongside a SHA256SUMS manifest.
+func Run(workspace, distDir string, req Request) (*Manifest, error) {
+	if err := validateName(req.Name); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(distDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	version, commit, commitEpoch, err := vcsInfo(workspace)
+	if err != nil {
+		return nil, fmt.Errorf("crossbuild: vcs info: %w", err)
+	}
+
+	targets := req.Targets
+	if len(targets) == 0 {
+		targets, err = SupportedTargets()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	type result struct {
+		artifact Artifact
+		err      error
+	}
+
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	results := make([]result, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t Target) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			artifact, err := buildOne(workspace, distDir, req, t, version, commit, commitEpoch)
+			results[i] = result{artifact, err}
+		}(i, t)
+	}
+	wg.Wait()
+
+	manifest := &Manifest{}
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		manifest.Artifacts = append(manifest.Artifacts, r.artifact)
+	}
+
+	sumsPath, err := writeChecksums(distDir, manifest.Artifacts)
+	if err != nil {
+		return nil, err
+	}
+	manifest.SHA256SumsPath = sumsPath
+	return manifest, nil
+}
+
+// validateName rejects a req.Name that would let binName escape distDir
+// when joined into a path, the same class of bug fixed for goproxy's
+// cachePathFor (commit 316d2bf): a client-controlled "../../tmp/evil"
+// would otherwise make go build write the binary outside distDir.
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("crossbuild: name must not be empty")
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("crossbuild: name %q must not contain a path separator", name)
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("crossbuild: name %q must not be a path segment", name)
+	}
+	return nil
+}
+
+func buildOne(workspace, distDir string, req Request, t Target, version, commit, commitEpoch string) (Artifact, error) {
+	ext := ""
+	if t.GOOS == "windows" {
+		ext = ".exe"
+	}
+	binName := fmt.Sprintf("%s_%s_%s_%s%s", req.Name, version, t.GOOS, t.GOARCH, ext)
+	binPath := filepath.Join(distDir, binName)
+
+	ldflags := fmt.Sprintf("-X main.version=%s -X main.commit=%s", version, commit)
+	args := []string{"build", "-o", binPath, "-ldflags", ldflags}
+	if req.Reproducible {
+		args = append(args, "-trimpath", "-buildvcs=false")
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = workspace
+	cmd.Env = append(os.Environ(),
+		"GOOS="+t.GOOS,
+		"GOARCH="+t.GOARCH,
+		cgoEnv(t.CGOEnabled),
+	)
+	if req.Reproducible {
+		cmd.Env = append(cmd.Env, "SOURCE_DATE_EPOCH="+commitEpoch)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return Artifact{}, fmt.Errorf("crossbuild: build %s/%s: %w: %s", t.GOOS, t.GOARCH, err, stderr.String())
+	}
+
+	artifact := Artifact{Target: t, Path: binPath}
+	if req.Archive != "" {
+		archivePath, err := archive(distDir, binPath, binName, req.Archive)
+		if err != nil {
+			return Artifact{}, err
+		}
+		artifact.Archive = archivePath
+	}
+
+	sum, err := sha256File(binPath)
+	if err != nil {
+		return Artifact{}, err
+	}
+	artifact.SHA256 = sum
+	return artifact, nil
+}
+
+func cgoEnv(enabled bool) string {
+	if enabled {
+		return "CGO_ENABLED=1"
+	}
+	return "CGO_ENABLED=0"
+}
+
+// SupportedTargets enumerates targets via `go tool dist list`.
+func SupportedTargets() ([]Target, error) {
+	out, err := exec.Command("go", "tool", "dist", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("crossbuild: dist list: %w", err)
+	}
+
+	var targets []Target
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.SplitN(line, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		targets = append(targets, Target{GOOS: parts[0], GOARCH: parts[1]})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].GOOS != targets[j].GOOS {
+			return targets[i].GOOS < targets[j].GOOS
+		}
+		return targets[i].GOARCH < targets[j].GOARCH
+	})
+	return targets, nil
+}
+
+// vcsInfo returns the workspace's short commit hash (for -ldflags), a
+// human-readable version (for artifact names), and the commit's committer
+// timestamp as a Unix epoch string, which is what SOURCE_DATE_EPOCH
+// requires for reproducible builds.
+func vcsInfo(workspace string) (version, commit, commitEpoch string, err error) {
+	commitOut, err := exec.Command("git", "-C", workspace, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", "", "", err
+	}
+	commit = strings.TrimSpace(string(commitOut))
+
+	epochOut, err := exec.Command("git", "-C", workspace, "show", "-s", "--format=%ct", commit).Output()
+	if err != nil {
+		return "", "", "", fmt.Errorf("resolve commit timestamp: %w", err)
+	}
+	commitEpoch = strings.TrimSpace(string(epochOut))
+
+	tagOut, err := exec.Command("git", "-C", workspace, "describe", "--tags", "--always").Output()
+	if err != nil {
+		version = commit
+	} else {
+		version = strings.TrimSpace(string(tagOut))
+	}
+	return version, commit, commitEpoch, nil
+}
+
+func archive(distDir, binPath, binName, kind string) (string, error) {
+	switch kind {
+	case "tar.gz":
+		out := filepath.Join(distDir, binName+".tar.gz")
+		cmd := exec.Command("tar", "-C", distDir, "-czf", out, binName)
+		return out, cmd.Run()
+	case "zip":
+		out := filepath.Join(distDir, binName+".zip")
+		cmd := exec.Command("zip", "-j", out, binPath)
+		return out, cmd.Run()
+	default:
+		return "", fmt.Errorf("crossbuild: unsupported archive type %q", kind)
+	}
+}
+
+func writeChecksums(distDir string, artifacts []Artifact) (string, error) {
+	path := filepath.Join(distDir, "SHA256SUMS")
+	var b strings.Builder
+	for _, a := range artifacts {
+		fmt.Fprintf(&b, "%s  %s\n", a.SHA256, filepath.Base(a.Path))
+	}
+	return path, os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
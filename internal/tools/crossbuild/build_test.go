@@ -0,0 +1,104 @@
+package crossbuild
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateNameRejectsPathTraversal(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "../../tmp/evil", "sub/evil", `sub\evil`} {
+		if err := validateName(name); err == nil {
+			t.Errorf("validateName(%q) = nil, want an error", name)
+		}
+	}
+}
+
+func TestValidateNameAcceptsOrdinaryNames(t *testing.T) {
+	for _, name := range []string{"myapp", "my-app_v2"} {
+		if err := validateName(name); err != nil {
+			t.Errorf("validateName(%q) = %v, want nil", name, err)
+		}
+	}
+}
+
+func TestSHA256File(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bin")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := sha256File(path)
+	if err != nil {
+		t.Fatalf("sha256File: %v", err)
+	}
+	const want = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if sum != want {
+		t.Errorf("sha256File = %s, want %s", sum, want)
+	}
+}
+
+func TestWriteChecksums(t *testing.T) {
+	dir := t.TempDir()
+	artifacts := []Artifact{
+		{Path: filepath.Join(dir, "app_linux_amd64"), SHA256: "aaaa"},
+		{Path: filepath.Join(dir, "app_darwin_arm64"), SHA256: "bbbb"},
+	}
+
+	path, err := writeChecksums(dir, artifacts)
+	if err != nil {
+		t.Fatalf("writeChecksums: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read sums file: %v", err)
+	}
+	got := string(data)
+	if !strings.Contains(got, "aaaa  app_linux_amd64\n") || !strings.Contains(got, "bbbb  app_darwin_arm64\n") {
+		t.Errorf("writeChecksums content = %q, missing expected lines", got)
+	}
+}
+
+func TestVCSInfoCommitEpochMatchesGitShow(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	workspace := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workspace
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	if err := os.WriteFile(filepath.Join(workspace, "f"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "f")
+	run("commit", "-q", "-m", "init")
+
+	_, commit, commitEpoch, err := vcsInfo(workspace)
+	if err != nil {
+		t.Fatalf("vcsInfo: %v", err)
+	}
+
+	wantCmd := exec.Command("git", "-C", workspace, "show", "-s", "--format=%ct", commit)
+	wantOut, err := wantCmd.Output()
+	if err != nil {
+		t.Fatalf("git show: %v", err)
+	}
+	want := strings.TrimSpace(string(wantOut))
+	if commitEpoch != want {
+		t.Errorf("commitEpoch = %q, want %q", commitEpoch, want)
+	}
+}
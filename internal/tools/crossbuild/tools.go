@@ -0,0 +1,25 @@
+package crossbuild
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Register wires go.build.cross onto s.
+func Register(s *mcp.Server, workspace string) {
+	distDir := filepath.Join(workspace, "dist")
+
+	s.Register(mcp.Tool{
+		Name:        "go.build.cross",
+		Description: "Cross-compile a target matrix into dist/ with a SHA256SUMS manifest",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var r Request
+			if err := json.Unmarshal(req.Params, &r); err != nil {
+				return nil, err
+			}
+			return Run(workspace, distDir, r)
+		},
+	})
+}
@@ -0,0 +1,401 @@
+// Package golsp bridges MCP tool calls onto a running gopls instance,
+// translating each call into the equivalent textDocument/* LSP request.
+package golsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// diagnosticsTimeout bounds how long Diagnostics waits for gopls to
+// publish results for a file it was just asked to (re-)open.
+const diagnosticsTimeout = 10 * time.Second
+
+// callTimeout bounds how long call waits for a response to a request,
+// so a stuck or unresponsive gopls can't hang the caller forever.
+const callTimeout = 30 * time.Second
+
+// Bridge owns a single gopls child process and speaks LSP to it over
+// stdio using Content-Length framing.
+type Bridge struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan *lspMessage
+
+	diagMu      sync.Mutex
+	diagWaiters map[string]chan json.RawMessage
+
+	// docsMu guards docVersions, which tracks the LSP version of every
+	// file this bridge has told gopls is open, so Diagnostics can send
+	// didChange (bumping the version) on an already-open file instead of
+	// re-sending didOpen, which is undefined behavior per the LSP spec.
+	docsMu      sync.Mutex
+	docVersions map[string]int
+
+	// dead is closed once readLoop exits (the gopls process died or its
+	// stdout was closed), so any in-flight call unblocks immediately
+	// instead of waiting out the full callTimeout.
+	dead chan struct{}
+
+	workspace string
+}
+
+// Start launches gopls rooted at workspace and completes the LSP
+// initialize handshake. If workspace has no go.mod, it is started in
+// GOPATH mode against a synthesized GOPATH so gopls doesn't fall back to
+// the degraded "no module" behavior described in vscode-go #1848.
+func Start(workspace string) (*Bridge, error) {
+	env := os.Environ()
+	if _, err := os.Stat(filepath.Join(workspace, "go.mod")); err != nil {
+		gopath, err := synthesizeGOPATH(workspace)
+		if err != nil {
+			return nil, fmt.Errorf("golsp: synthesize GOPATH: %w", err)
+		}
+		env = append(env, "GO111MODULE=off", "GOPATH="+gopath)
+	}
+
+	cmd := exec.Command("gopls", "-mode=stdio")
+	cmd.Dir = workspace
+	cmd.Env = env
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("golsp: start gopls: %w", err)
+	}
+
+	b := &Bridge{
+		cmd:         cmd,
+		stdin:       stdin,
+		stdout:      bufio.NewReader(stdout),
+		pending:     make(map[int64]chan *lspMessage),
+		diagWaiters: make(map[string]chan json.RawMessage),
+		docVersions: make(map[string]int),
+		dead:        make(chan struct{}),
+		workspace:   workspace,
+	}
+	go b.readLoop()
+
+	if _, err := b.call("initialize", map[string]any{
+		"processId":    os.Getpid(),
+		"rootUri":      "file://" + workspace,
+		"capabilities": map[string]any{},
+	}); err != nil {
+		b.Close()
+		return nil, fmt.Errorf("golsp: initialize: %w", err)
+	}
+	if err := b.notify("initialized", map[string]any{}); err != nil {
+		b.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// synthesizeGOPATH builds a throwaway GOPATH with workspace symlinked
+// under src/, mirroring the layout legacy GOPATH-mode tooling expects
+// when no go.mod is present.
+func synthesizeGOPATH(workspace string) (string, error) {
+	gopath := filepath.Join(os.TempDir(), "opendoor-gopath")
+	src := filepath.Join(gopath, "src", "workspace")
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		return "", err
+	}
+	if _, err := os.Lstat(src); os.IsNotExist(err) {
+		if err := os.Symlink(workspace, src); err != nil {
+			return "", err
+		}
+	}
+	return gopath, nil
+}
+
+// Close tells gopls every file this bridge opened is now closed, then
+// shuts down the gopls child process.
+func (b *Bridge) Close() error {
+	b.docsMu.Lock()
+	uris := make([]string, 0, len(b.docVersions))
+	for uri := range b.docVersions {
+		uris = append(uris, uri)
+	}
+	b.docVersions = make(map[string]int)
+	b.docsMu.Unlock()
+	for _, uri := range uris {
+		b.notify("textDocument/didClose", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+		})
+	}
+
+	b.stdin.Close()
+	return b.cmd.Wait()
+}
+
+// DidChangeWatchedFiles forwards a batch of file-change events to gopls
+// so its view of the workspace stays coherent across a long-running
+// session even when edits arrive out-of-band from the MCP filesystem tool.
+func (b *Bridge) DidChangeWatchedFiles(changes []FileEvent) error {
+	events := make([]map[string]any, 0, len(changes))
+	for _, c := range changes {
+		events = append(events, map[string]any{
+			"uri":  "file://" + c.Path,
+			"type": c.Type,
+		})
+	}
+	return b.notify("workspace/didChangeWatchedFiles", map[string]any{"changes": events})
+}
+
+// FileEvent describes one watched-file change, using the LSP
+// FileChangeType encoding (1=created, 2=changed, 3=deleted).
+type FileEvent struct {
+	Path string `json:"path"`
+	Type int    `json:"type"`
+}
+
+// Definition resolves textDocument/definition at the given position.
+func (b *Bridge) Definition(path string, line, col int) (json.RawMessage, error) {
+	return b.call("textDocument/definition", textDocumentPositionParams(path, line, col))
+}
+
+// References resolves textDocument/references at the given position.
+func (b *Bridge) References(path string, line, col int) (json.RawMessage, error) {
+	params := textDocumentPositionParams(path, line, col)
+	params["context"] = map[string]any{"includeDeclaration": true}
+	return b.call("textDocument/references", params)
+}
+
+// Hover resolves textDocument/hover at the given position.
+func (b *Bridge) Hover(path string, line, col int) (json.RawMessage, error) {
+	return b.call("textDocument/hover", textDocumentPositionParams(path, line, col))
+}
+
+// Diagnostics asks gopls to (re-)publish diagnostics for a file, then
+// waits for the textDocument/publishDiagnostics notification gopls
+// sends back asynchronously for that URI. The first call for a given
+// file sends didOpen; subsequent calls send didChange with a bumped
+// version instead, since re-sending didOpen on an already-open URI is
+// undefined behavior per the LSP spec.
+func (b *Bridge) Diagnostics(path string) (json.RawMessage, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := "file://" + path
+	wait := b.awaitDiagnostics(uri)
+	if err := b.openOrChange(uri, string(contents)); err != nil {
+		b.cancelDiagnostics(uri)
+		return nil, err
+	}
+
+	select {
+	case diagnostics := <-wait:
+		return diagnostics, nil
+	case <-time.After(diagnosticsTimeout):
+		b.cancelDiagnostics(uri)
+		return nil, fmt.Errorf("golsp: timed out waiting for diagnostics on %s", path)
+	}
+}
+
+// openOrChange sends didOpen the first time uri is seen and didChange
+// (full-document sync, version bumped) on every call after that.
+func (b *Bridge) openOrChange(uri, text string) error {
+	b.docsMu.Lock()
+	version, open := b.docVersions[uri]
+	version++
+	b.docVersions[uri] = version
+	b.docsMu.Unlock()
+
+	if !open {
+		return b.notify("textDocument/didOpen", map[string]any{
+			"textDocument": map[string]any{
+				"uri":        uri,
+				"languageId": "go",
+				"version":    version,
+				"text":       text,
+			},
+		})
+	}
+	return b.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": version},
+		"contentChanges": []map[string]any{{"text": text}},
+	})
+}
+
+// awaitDiagnostics registers a waiter for the next publishDiagnostics
+// notification gopls sends for uri.
+func (b *Bridge) awaitDiagnostics(uri string) <-chan json.RawMessage {
+	ch := make(chan json.RawMessage, 1)
+	b.diagMu.Lock()
+	b.diagWaiters[uri] = ch
+	b.diagMu.Unlock()
+	return ch
+}
+
+func (b *Bridge) cancelDiagnostics(uri string) {
+	b.diagMu.Lock()
+	delete(b.diagWaiters, uri)
+	b.diagMu.Unlock()
+}
+
+// handlePublishDiagnostics delivers an incoming publishDiagnostics
+// notification to whichever Diagnostics call is waiting on its URI, if
+// any; notifications that arrive with no waiter are dropped.
+func (b *Bridge) handlePublishDiagnostics(params json.RawMessage) {
+	var payload struct {
+		URI         string          `json:"uri"`
+		Diagnostics json.RawMessage `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(params, &payload); err != nil {
+		return
+	}
+
+	b.diagMu.Lock()
+	ch, ok := b.diagWaiters[payload.URI]
+	if ok {
+		delete(b.diagWaiters, payload.URI)
+	}
+	b.diagMu.Unlock()
+	if ok {
+		ch <- payload.Diagnostics
+	}
+}
+
+// Rename resolves textDocument/rename at the given position.
+func (b *Bridge) Rename(path string, line, col int, newName string) (json.RawMessage, error) {
+	params := textDocumentPositionParams(path, line, col)
+	params["newName"] = newName
+	return b.call("textDocument/rename", params)
+}
+
+func textDocumentPositionParams(path string, line, col int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": "file://" + path},
+		"position":     map[string]any{"line": line, "character": col},
+	}
+}
+
+// lspResponseError mirrors a JSON-RPC error response, e.g. a failed
+// textDocument/rename on an out-of-range position.
+type lspResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspMessage struct {
+	ID     int64             `json:"id,omitempty"`
+	Method string            `json:"method,omitempty"`
+	Params json.RawMessage   `json:"params,omitempty"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  *lspResponseError `json:"error,omitempty"`
+}
+
+func (b *Bridge) call(method string, params map[string]any) (json.RawMessage, error) {
+	id := atomic.AddInt64(&b.nextID, 1)
+	ch := make(chan *lspMessage, 1)
+	b.mu.Lock()
+	b.pending[id] = ch
+	b.mu.Unlock()
+
+	if err := b.write(map[string]any{
+		"jsonrpc": "2.0", "id": id, "method": method, "params": params,
+	}); err != nil {
+		b.mu.Lock()
+		delete(b.pending, id)
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case msg := <-ch:
+		if msg.Error != nil {
+			return nil, fmt.Errorf("golsp: %s: %s (code %d)", method, msg.Error.Message, msg.Error.Code)
+		}
+		return msg.Result, nil
+	case <-b.dead:
+		b.mu.Lock()
+		delete(b.pending, id)
+		b.mu.Unlock()
+		return nil, fmt.Errorf("golsp: gopls exited while waiting for %s", method)
+	case <-time.After(callTimeout):
+		b.mu.Lock()
+		delete(b.pending, id)
+		b.mu.Unlock()
+		return nil, fmt.Errorf("golsp: timed out waiting for %s", method)
+	}
+}
+
+func (b *Bridge) notify(method string, params map[string]any) error {
+	return b.write(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (b *Bridge) write(msg map[string]any) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(b.stdin, "Content-Length: %d\r\n\r\n%s", len(payload), payload)
+	return err
+}
+
+func (b *Bridge) readLoop() {
+	defer close(b.dead)
+	for {
+		msg, err := readLSPMessage(b.stdout)
+		if err != nil {
+			return
+		}
+		if msg.ID == 0 {
+			if msg.Method == "textDocument/publishDiagnostics" {
+				b.handlePublishDiagnostics(msg.Params)
+			}
+			continue
+		}
+		b.mu.Lock()
+		ch, ok := b.pending[msg.ID]
+		delete(b.pending, msg.ID)
+		b.mu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+func readLSPMessage(r *bufio.Reader) (*lspMessage, error) {
+	var length int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if line == "\r\n" {
+			break
+		}
+		fmt.Sscanf(line, "Content-Length: %d", &length)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(buf, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
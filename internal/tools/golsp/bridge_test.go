@@ -0,0 +1,182 @@
+package golsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nopWriteCloser discards everything written to it, standing in for
+// gopls's stdin pipe in tests that only exercise call's response
+// handling, not the request it sends.
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) { return io.Discard.Write(p) }
+func (nopWriteCloser) Close() error                { return nil }
+
+func TestReadLSPMessage(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"result":{"ok":true}}`
+	raw := "Content-Length: " + itoa(len(body)) + "\r\n\r\n" + body
+
+	msg, err := readLSPMessage(bufio.NewReader(strings.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("readLSPMessage: %v", err)
+	}
+	if msg.ID != 1 {
+		t.Errorf("ID = %d, want 1", msg.ID)
+	}
+	if string(msg.Result) != `{"ok":true}` {
+		t.Errorf("Result = %s, want {\"ok\":true}", msg.Result)
+	}
+}
+
+func TestReadLSPMessageNotification(t *testing.T) {
+	body := `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"file:///a.go","diagnostics":[]}}`
+	raw := "Content-Length: " + itoa(len(body)) + "\r\n\r\n" + body
+
+	msg, err := readLSPMessage(bufio.NewReader(strings.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("readLSPMessage: %v", err)
+	}
+	if msg.ID != 0 {
+		t.Errorf("ID = %d, want 0 for a notification", msg.ID)
+	}
+	if msg.Method != "textDocument/publishDiagnostics" {
+		t.Errorf("Method = %q, want textDocument/publishDiagnostics", msg.Method)
+	}
+}
+
+func TestHandlePublishDiagnosticsDeliversToWaiter(t *testing.T) {
+	b := &Bridge{diagWaiters: make(map[string]chan json.RawMessage)}
+	uri := "file:///a.go"
+	wait := b.awaitDiagnostics(uri)
+
+	b.handlePublishDiagnostics(json.RawMessage(`{"uri":"file:///a.go","diagnostics":[{"message":"boom"}]}`))
+
+	select {
+	case diagnostics := <-wait:
+		if string(diagnostics) != `[{"message":"boom"}]` {
+			t.Errorf("diagnostics = %s, want [{\"message\":\"boom\"}]", diagnostics)
+		}
+	default:
+		t.Fatal("handlePublishDiagnostics did not deliver to the waiting channel")
+	}
+}
+
+func TestHandlePublishDiagnosticsNoWaiterDoesNotPanic(t *testing.T) {
+	b := &Bridge{diagWaiters: make(map[string]chan json.RawMessage)}
+	b.handlePublishDiagnostics(json.RawMessage(`{"uri":"file:///unwatched.go","diagnostics":[]}`))
+}
+
+func TestCallSurfacesRPCError(t *testing.T) {
+	b := &Bridge{
+		stdin:   nopWriteCloser{},
+		pending: make(map[int64]chan *lspMessage),
+		dead:    make(chan struct{}),
+	}
+
+	type outcome struct {
+		result json.RawMessage
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := b.call("textDocument/rename", map[string]any{})
+		done <- outcome{result, err}
+	}()
+
+	var ch chan *lspMessage
+	for ch == nil {
+		b.mu.Lock()
+		for _, c := range b.pending {
+			ch = c
+		}
+		b.mu.Unlock()
+	}
+	ch <- &lspMessage{Error: &lspResponseError{Code: -32602, Message: "invalid params"}}
+
+	o := <-done
+	if o.err == nil {
+		t.Fatal("call: expected an error for a JSON-RPC error response, got nil")
+	}
+	if !strings.Contains(o.err.Error(), "invalid params") {
+		t.Errorf("call error = %v, want it to mention the RPC error message", o.err)
+	}
+}
+
+func TestCallUnblocksWhenBridgeDies(t *testing.T) {
+	b := &Bridge{
+		stdin:   nopWriteCloser{},
+		pending: make(map[int64]chan *lspMessage),
+		dead:    make(chan struct{}),
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.call("textDocument/hover", map[string]any{})
+		done <- err
+	}()
+	close(b.dead)
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("call: expected an error once the bridge died, got nil")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("call did not unblock after the bridge died")
+	}
+}
+
+// bufWriteCloser captures everything written to it, standing in for
+// gopls's stdin pipe in tests that need to inspect the requests sent.
+type bufWriteCloser struct{ bytes.Buffer }
+
+func (*bufWriteCloser) Close() error { return nil }
+
+func TestOpenOrChangeSendsDidOpenThenDidChange(t *testing.T) {
+	var buf bufWriteCloser
+	b := &Bridge{
+		stdin:       &buf,
+		docVersions: make(map[string]int),
+	}
+
+	if err := b.openOrChange("file:///a.go", "package a"); err != nil {
+		t.Fatalf("openOrChange (first call): %v", err)
+	}
+	if !strings.Contains(buf.String(), `"method":"textDocument/didOpen"`) {
+		t.Errorf("first openOrChange should send didOpen, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"version":1`) {
+		t.Errorf("first openOrChange should open at version 1, got %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := b.openOrChange("file:///a.go", "package a // edited"); err != nil {
+		t.Fatalf("openOrChange (second call): %v", err)
+	}
+	if !strings.Contains(buf.String(), `"method":"textDocument/didChange"`) {
+		t.Errorf("repeat openOrChange should send didChange, not reopen, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"version":2`) {
+		t.Errorf("repeat openOrChange should bump the version, got %s", buf.String())
+	}
+}
+
+func itoa(n int) string {
+	var buf bytes.Buffer
+	if n == 0 {
+		return "0"
+	}
+	digits := []byte{}
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	buf.Write(digits)
+	return buf.String()
+}
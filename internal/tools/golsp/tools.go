@@ -0,0 +1,97 @@
+package golsp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+type positionParams struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+}
+
+type renameParams struct {
+	positionParams
+	NewName string `json:"newName"`
+}
+
+// Register wires go.definition, go.references, go.hover, go.diagnostics,
+// go.rename and go.didChangeWatchedFiles onto s, all backed by a single
+// Bridge rooted at workspace.
+func Register(s *mcp.Server, workspace string) error {
+	bridge, err := Start(workspace)
+	if err != nil {
+		return fmt.Errorf("golsp: %w", err)
+	}
+
+	s.Register(mcp.Tool{
+		Name:        "go.definition",
+		Description: "Resolve the definition of the symbol at a position",
+		Handler:     positionHandler(bridge.Definition),
+	})
+	s.Register(mcp.Tool{
+		Name:        "go.references",
+		Description: "Find references to the symbol at a position",
+		Handler:     positionHandler(bridge.References),
+	})
+	s.Register(mcp.Tool{
+		Name:        "go.hover",
+		Description: "Show hover information for the symbol at a position",
+		Handler:     positionHandler(bridge.Hover),
+	})
+	s.Register(mcp.Tool{
+		Name:        "go.diagnostics",
+		Description: "Refresh and return diagnostics for a file",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			return bridge.Diagnostics(p.Path)
+		},
+	})
+	s.Register(mcp.Tool{
+		Name:        "go.rename",
+		Description: "Rename the symbol at a position",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p renameParams
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			return bridge.Rename(p.Path, p.Line, p.Col, p.NewName)
+		},
+	})
+	s.Register(mcp.Tool{
+		Name:        "go.didChangeWatchedFiles",
+		Description: "Forward file-change events from the MCP host's edits to gopls",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Changes []FileEvent `json:"changes"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			if err := bridge.DidChangeWatchedFiles(p.Changes); err != nil {
+				return nil, err
+			}
+			return map[string]int{"forwarded": len(p.Changes)}, nil
+		},
+	})
+
+	return nil
+}
+
+func positionHandler(call func(path string, line, col int) (json.RawMessage, error)) func(*mcp.Request, *mcp.Server) (any, error) {
+	return func(req *mcp.Request, _ *mcp.Server) (any, error) {
+		var p positionParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return call(p.Path, p.Line, p.Col)
+	}
+}
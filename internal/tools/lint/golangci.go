@@ -0,0 +1,200 @@
+// Package lint wraps golangci-lint as the go.lint MCP tool, working
+// around the "no go files to analyze" failure mode golangci-lint hits
+// when GOPATH is unset or the target isn't inside a module
+// (golangci-lint #3828).
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Diagnostic is one finding, normalized from golangci-lint's
+// --out-format=json output.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Col      int    `json:"col"`
+	Severity string `json:"severity"`
+	Linter   string `json:"linter"`
+	Message  string `json:"message"`
+}
+
+// defaultConfig is used when the workspace has no .golangci.yml and the
+// caller didn't supply one either.
+const defaultConfig = `linters:
+  enable:
+    - govet
+    - staticcheck
+    - errcheck
+    - ineffassign
+`
+
+// Run lints dir, resolving config via client JSON -> .golangci.yml in
+// the workspace -> defaultConfig, and always injecting a resolved
+// GOPATH so the child process can't hit the unset-GOPATH failure mode.
+func Run(dir string, clientConfig []byte) ([]Diagnostic, error) {
+	configPath, cleanup, err := resolveConfig(dir, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	root, rootCleanup, err := moduleRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("lint: %w", err)
+	}
+	defer rootCleanup()
+
+	gopath, err := goEnv("GOPATH")
+	if err != nil {
+		return nil, fmt.Errorf("lint: resolve GOPATH: %w", err)
+	}
+
+	cmd := exec.Command("golangci-lint", "run", "--out-format=json", "-c", configPath, "./...")
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "GOPATH="+gopath)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	// golangci-lint exits non-zero when it finds issues; that's not a
+	// tool failure, only an empty/invalid stdout is.
+	_ = cmd.Run()
+
+	return parseResults(stdout.Bytes())
+}
+
+func resolveConfig(dir string, clientConfig []byte) (path string, cleanup func(), err error) {
+	if len(clientConfig) > 0 {
+		f, err := os.CreateTemp("", "golangci-*.yml")
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := f.Write(clientConfig); err != nil {
+			f.Close()
+			return "", nil, err
+		}
+		f.Close()
+		return f.Name(), func() { os.Remove(f.Name()) }, nil
+	}
+
+	if existing := filepath.Join(dir, ".golangci.yml"); fileExists(existing) {
+		return existing, func() {}, nil
+	}
+
+	f, err := os.CreateTemp("", "golangci-default-*.yml")
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := f.WriteString(defaultConfig); err != nil {
+		f.Close()
+		return "", nil, err
+	}
+	f.Close()
+	return f.Name(), func() { os.Remove(f.Name()) }, nil
+}
+
+// moduleRoot walks up from dir looking for go.mod. If none is found,
+// the workspace predates module mode, so we synthesize one in a scratch
+// copy rather than fail outright; the returned cleanup removes that
+// scratch copy once the caller is done linting.
+func moduleRoot(dir string) (root string, cleanup func(), err error) {
+	for d := dir; d != "/" && d != "."; d = filepath.Dir(d) {
+		if fileExists(filepath.Join(d, "go.mod")) {
+			return d, func() {}, nil
+		}
+	}
+	return synthesizeModule(dir)
+}
+
+// synthesizeModule builds a throwaway module root in a scratch
+// directory with dir's contents symlinked in, and runs `go mod init`
+// there. It never writes into dir itself: go.lint only reads a
+// workspace, it doesn't mutate it.
+func synthesizeModule(dir string) (root string, cleanup func(), err error) {
+	scratch, err := os.MkdirTemp("", "opendoor-lint-scratch-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(scratch) }
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	for _, e := range entries {
+		if err := os.Symlink(filepath.Join(dir, e.Name()), filepath.Join(scratch, e.Name())); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+	}
+
+	cmd := exec.Command("go", "mod", "init", "opendoor-lint-scratch")
+	cmd.Dir = scratch
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("synthesize module root: %w", err)
+	}
+	return scratch, cleanup, nil
+}
+
+func goEnv(name string) (string, error) {
+	out, err := exec.Command("go", "env", name).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// golangciIssue mirrors the subset of golangci-lint's --out-format=json
+// schema we normalize into a Diagnostic.
+type golangciIssue struct {
+	FromLinter string `json:"FromLinter"`
+	Text       string `json:"Text"`
+	Severity   string `json:"Severity"`
+	Pos        struct {
+		Filename string `json:"Filename"`
+		Line     int    `json:"Line"`
+		Column   int    `json:"Column"`
+	} `json:"Pos"`
+}
+
+type golangciReport struct {
+	Issues []golangciIssue `json:"Issues"`
+}
+
+func parseResults(output []byte) ([]Diagnostic, error) {
+	var report golangciReport
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, fmt.Errorf("lint: parse golangci-lint output: %w", err)
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		severity := issue.Severity
+		if severity == "" {
+			severity = "error"
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     issue.Pos.Filename,
+			Line:     issue.Pos.Line,
+			Col:      issue.Pos.Column,
+			Severity: severity,
+			Linter:   issue.FromLinter,
+			Message:  issue.Text,
+		})
+	}
+	return diagnostics, nil
+}
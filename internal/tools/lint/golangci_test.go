@@ -0,0 +1,53 @@
+package lint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSynthesizeModuleDoesNotMutateWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	root, cleanup, err := synthesizeModule(dir)
+	if err != nil {
+		t.Fatalf("synthesizeModule: %v", err)
+	}
+	defer cleanup()
+
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); !os.IsNotExist(err) {
+		t.Errorf("synthesizeModule wrote go.mod into the real workspace %s", dir)
+	}
+	if _, err := os.Stat(filepath.Join(root, "go.mod")); err != nil {
+		t.Errorf("synthesizeModule did not write go.mod into the scratch root %s: %v", root, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "main.go")); err != nil {
+		t.Errorf("synthesizeModule did not link dir's contents into the scratch root: %v", err)
+	}
+}
+
+func TestParseResults(t *testing.T) {
+	input := `{"Issues":[{"FromLinter":"govet","Text":"bad thing","Severity":"","Pos":{"Filename":"foo.go","Line":3,"Column":5}}]}`
+	diagnostics, err := parseResults([]byte(input))
+	if err != nil {
+		t.Fatalf("parseResults: %v", err)
+	}
+	if len(diagnostics) != 1 {
+		t.Fatalf("parseResults returned %d diagnostics, want 1", len(diagnostics))
+	}
+	d := diagnostics[0]
+	if d.File != "foo.go" || d.Line != 3 || d.Col != 5 || d.Linter != "govet" || d.Message != "bad thing" {
+		t.Errorf("parseResults[0] = %+v, unexpected fields", d)
+	}
+	if d.Severity != "error" {
+		t.Errorf("Severity = %q, want default of %q", d.Severity, "error")
+	}
+}
@@ -0,0 +1,28 @@
+package lint
+
+import (
+	"encoding/json"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Register wires go.lint onto s.
+func Register(s *mcp.Server, workspace string) {
+	s.Register(mcp.Tool{
+		Name:        "go.lint",
+		Description: "Run golangci-lint and return structured diagnostics",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Config json.RawMessage `json:"config"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			diagnostics, err := Run(workspace, p.Config)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"diagnostics": diagnostics}, nil
+		},
+	})
+}
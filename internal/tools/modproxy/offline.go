@@ -0,0 +1,54 @@
+// Package modproxy exposes go.mod.offline, which flips the container
+// between resolving modules through the local goproxy (with upstream
+// fallback) and running fully offline against the prefetched cache.
+package modproxy
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// LocalAddr is the loopback address the local goproxy listens on. It's
+// shared with cmd/mcp-go-agent, which polls it before serving MCP
+// requests so the first network-dependent tool call doesn't race the
+// listener coming up.
+const LocalAddr = "127.0.0.1:8081"
+
+// OnlineGOPROXY is the default chain: the local goproxy's loopback HTTP
+// listener first, the real upstream second, then Go's own "direct"
+// fallback. GOPROXY only understands http/https/file schemes, so the
+// local proxy is reached over TCP, not a UNIX socket. The local entry
+// is followed by `|` rather than `,` so cmd/go falls back to the rest
+// of the chain on any error from the local proxy (connection refused,
+// timeout, ...), not just a 404/410 "not found" response.
+const OnlineGOPROXY = "http://" + LocalAddr + "|https://proxy.golang.org,direct"
+
+// SetOffline prefetches every dependency of workspace's go.mod and then
+// switches GOPROXY=off so subsequent builds can't reach the network.
+// Passing offline=false restores the normal online proxy chain.
+func SetOffline(workspace string, offline bool) error {
+	if !offline {
+		return goEnvWrite(workspace, "GOPROXY", OnlineGOPROXY)
+	}
+
+	cmd := exec.Command("go", "mod", "download", "all")
+	cmd.Dir = workspace
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("modproxy: prefetch dependencies: %w", err)
+	}
+
+	return goEnvWrite(workspace, "GOPROXY", "off")
+}
+
+func goEnvWrite(workspace, key, value string) error {
+	cmd := exec.Command("go", "env", "-w", key+"="+value)
+	cmd.Dir = workspace
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("modproxy: go env -w %s=%s: %w", key, value, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package modproxy
+
+import (
+	"encoding/json"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Register wires go.mod.offline onto s.
+func Register(s *mcp.Server, workspace string) {
+	s.Register(mcp.Tool{
+		Name:        "go.mod.offline",
+		Description: "Prefetch all dependencies and flip GOPROXY=off for hermetic, offline builds",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Offline bool `json:"offline"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			if err := SetOffline(workspace, p.Offline); err != nil {
+				return nil, err
+			}
+			return map[string]bool{"offline": p.Offline}, nil
+		},
+	})
+}
@@ -0,0 +1,53 @@
+// Package toolchain exposes internal/toolchain as MCP tools so a
+// client can pin or switch the Go SDK a request runs against.
+package toolchain
+
+import (
+	"encoding/json"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+	internal "github.com/smishi204/opendoor/internal/toolchain"
+)
+
+// Register wires go.toolchain and go.toolchain.list onto s.
+func Register(s *mcp.Server) error {
+	mgr, err := internal.NewManager()
+	if err != nil {
+		return err
+	}
+
+	s.Register(mcp.Tool{
+		Name:        "go.toolchain",
+		Description: "Install and switch to a pinned Go SDK version",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			if err := mgr.Use(p.Version); err != nil {
+				return nil, err
+			}
+			return map[string]string{"version": p.Version, "current": internal.CurrentLink}, nil
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.toolchain.list",
+		Description: "List Go SDK versions installed in this container and available to install",
+		Handler: func(_ *mcp.Request, _ *mcp.Server) (any, error) {
+			installed, err := mgr.Installed()
+			if err != nil {
+				return nil, err
+			}
+			available, err := internal.Available()
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"installed": installed, "available": available}, nil
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package watch runs `air` as a long-lived MCP tool, streaming its
+// build/run output back to the client as structured notifications.
+package watch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Stream classifies one line of air output for the client, since air
+// interleaves its own rebuild chatter with the program's own stdout.
+type Stream string
+
+const (
+	StreamBuildError   Stream = "build-error"
+	StreamRuntimeLog   Stream = "runtime-log"
+	StreamRebuildEvent Stream = "rebuild-event"
+)
+
+// Session is one running `air` process and its notification pump.
+type Session struct {
+	cmd    *exec.Cmd
+	cancel func()
+
+	mu       sync.Mutex
+	status   string
+	debounce *debouncer
+}
+
+var (
+	sessionsMu sync.Mutex
+	sessions   = map[string]*Session{} // keyed by workspace
+)
+
+// Start launches air in workspace, auto-generating .air.toml if none
+// exists, and streams its output to s as notifications tagged id.
+func Start(s *mcp.Server, id, workspace string) (*Session, error) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	if _, running := sessions[workspace]; running {
+		return nil, fmt.Errorf("watch: a session is already running for %s", workspace)
+	}
+
+	if err := ensureAirConfig(workspace); err != nil {
+		return nil, fmt.Errorf("watch: write .air.toml: %w", err)
+	}
+
+	cmd := exec.Command("air", "-c", ".air.toml")
+	cmd.Dir = workspace
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("watch: start air: %w", err)
+	}
+
+	session := &Session{cmd: cmd, status: "running", debounce: newDebouncer(cmd.Process)}
+	go session.pump(s, id, stdout, false)
+	go session.pump(s, id, stderr, true)
+	go cmd.Wait() // reap air on exit, whether Stop kills it or it dies on its own
+
+	sessions[workspace] = session
+	return session, nil
+}
+
+// Stop terminates the air process for workspace.
+func Stop(workspace string) error {
+	sessionsMu.Lock()
+	session, ok := sessions[workspace]
+	if ok {
+		delete(sessions, workspace)
+	}
+	sessionsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("watch: no session running for %s", workspace)
+	}
+	session.mu.Lock()
+	session.status = "stopped"
+	session.mu.Unlock()
+	return session.cmd.Process.Kill()
+}
+
+// Status reports whether a session is running for workspace.
+func Status(workspace string) string {
+	sessionsMu.Lock()
+	session, ok := sessions[workspace]
+	sessionsMu.Unlock()
+	if !ok {
+		return "stopped"
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.status
+}
+
+// NotifyWrite debounces a file write coming through the MCP filesystem
+// tool so a burst of edits triggers one air rebuild, not one per file.
+func NotifyWrite(workspace, path string) {
+	sessionsMu.Lock()
+	session, ok := sessions[workspace]
+	sessionsMu.Unlock()
+	if ok {
+		session.debounce.notify(path)
+	}
+}
+
+func (s *Session) pump(server *mcp.Server, id string, r io.Reader, isStderr bool) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		server.Notify(mcp.Notification{
+			ID:     id,
+			Tool:   "go.watch",
+			Stream: string(classify(line, isStderr)),
+			Data:   line,
+		})
+	}
+}
+
+// classify buckets a line of air output so clients can render build
+// failures, rebuild markers and program output in separate panes.
+func classify(line string, isStderr bool) Stream {
+	switch {
+	case strings.Contains(line, "building..."), strings.Contains(line, "running..."):
+		return StreamRebuildEvent
+	case isStderr:
+		return StreamBuildError
+	default:
+		return StreamRuntimeLog
+	}
+}
+
+// ensureAirConfig writes a sensible .air.toml if one doesn't already
+// exist in workspace, locating main.go and excluding vendor/test dirs
+// that shouldn't trigger rebuilds.
+func ensureAirConfig(workspace string) error {
+	path := filepath.Join(workspace, ".air.toml")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	mainPath := findMain(workspace)
+	config := fmt.Sprintf(`root = "."
+tmp_dir = ".opendoor/air-tmp"
+
+[build]
+cmd = "go build -o ./.opendoor/air-tmp/app %s"
+bin = "./.opendoor/air-tmp/app"
+exclude_dir = ["vendor", ".git", "testdata", ".opendoor"]
+include_ext = ["go"]
+delay = 300
+
+[log]
+time = true
+`, mainPath)
+	return os.WriteFile(path, []byte(config), 0o644)
+}
+
+func findMain(workspace string) string {
+	if _, err := os.Stat(filepath.Join(workspace, "main.go")); err == nil {
+		return "."
+	}
+	found := "."
+	filepath.Walk(workspace, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if filepath.Base(p) == "main.go" {
+			found = "./" + filepath.Dir(strings.TrimPrefix(p, workspace+"/"))
+			return io.EOF // first match wins; stop walking
+		}
+		return nil
+	})
+	return found
+}
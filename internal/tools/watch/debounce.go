@@ -0,0 +1,57 @@
+package watch
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// debounceWindow batches writes that land within this window of each
+// other into a single flush, so a multi-file save from the MCP
+// filesystem tool triggers one air rebuild instead of a storm of them.
+const debounceWindow = 200 * time.Millisecond
+
+// debouncer coalesces a burst of file writes for one air session by
+// actually pausing the air process (SIGSTOP) for the duration of the
+// burst and resuming it (SIGCONT) once writes go quiet, so air's own
+// fsnotify watch only ever observes the settled state.
+type debouncer struct {
+	proc *os.Process
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+func newDebouncer(proc *os.Process) *debouncer {
+	return &debouncer{proc: proc}
+}
+
+// notify records a write, resetting the flush timer. The first write
+// of a burst suspends air; it stays suspended until the window elapses
+// with no further activity.
+func (d *debouncer) notify(path string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.stopped {
+		if err := d.proc.Signal(syscall.SIGSTOP); err == nil {
+			d.stopped = true
+		}
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(debounceWindow, d.flush)
+}
+
+// flush resumes air once a burst of writes has settled.
+func (d *debouncer) flush() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		d.proc.Signal(syscall.SIGCONT)
+		d.stopped = false
+	}
+}
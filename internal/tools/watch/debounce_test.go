@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// procState returns the single-character state field from
+// /proc/<pid>/stat ('T' = stopped, 'S'/'R' = running), skipping the test
+// on platforms where /proc isn't available.
+func procState(t *testing.T, pid int) string {
+	t.Helper()
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		t.Skipf("/proc not available: %v", err)
+	}
+	// Fields are "pid (comm) state ...": comm may itself contain spaces
+	// or parens, so split after the last ')'.
+	fields := strings.Fields(string(data)[strings.LastIndex(string(data), ")")+1:])
+	return fields[0]
+}
+
+func TestDebouncerNotifyStopsAndFlushResumesProcess(t *testing.T) {
+	cmd := exec.Command("sleep", "5")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("sleep not available: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	d := newDebouncer(cmd.Process)
+	d.notify("foo.go")
+
+	deadline := time.Now().Add(time.Second)
+	for procState(t, cmd.Process.Pid) != "T" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := procState(t, cmd.Process.Pid); got != "T" {
+		t.Fatalf("process state after notify = %q, want T (stopped)", got)
+	}
+
+	d.flush()
+
+	deadline = time.Now().Add(time.Second)
+	for procState(t, cmd.Process.Pid) == "T" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := procState(t, cmd.Process.Pid); got == "T" {
+		t.Fatalf("process state after flush = %q, want resumed", got)
+	}
+}
@@ -0,0 +1,56 @@
+package watch
+
+import (
+	"encoding/json"
+
+	"github.com/smishi204/opendoor/internal/mcp"
+)
+
+// Register wires go.watch, go.watch.status, go.watch.stop and
+// go.watch.notifyWrite onto s.
+func Register(s *mcp.Server, workspace string) {
+	s.Register(mcp.Tool{
+		Name:        "go.watch",
+		Description: "Start an air live-reload session and stream build/run output",
+		Handler: func(req *mcp.Request, server *mcp.Server) (any, error) {
+			if _, err := Start(server, req.ID, workspace); err != nil {
+				return nil, err
+			}
+			return map[string]string{"status": "running"}, nil
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.watch.status",
+		Description: "Report whether a go.watch session is running",
+		Handler: func(_ *mcp.Request, _ *mcp.Server) (any, error) {
+			return map[string]string{"status": Status(workspace)}, nil
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.watch.stop",
+		Description: "Stop the running go.watch session",
+		Handler: func(_ *mcp.Request, _ *mcp.Server) (any, error) {
+			if err := Stop(workspace); err != nil {
+				return nil, err
+			}
+			return map[string]string{"status": "stopped"}, nil
+		},
+	})
+
+	s.Register(mcp.Tool{
+		Name:        "go.watch.notifyWrite",
+		Description: "Report a file write from the MCP filesystem tool so the watch session can debounce its rebuild",
+		Handler: func(req *mcp.Request, _ *mcp.Server) (any, error) {
+			var p struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, err
+			}
+			NotifyWrite(workspace, p.Path)
+			return map[string]string{"status": "ok"}, nil
+		},
+	})
+}